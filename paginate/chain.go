@@ -0,0 +1,91 @@
+package paginate
+
+import (
+	"net/url"
+	"regexp"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andrew-d/goscrape"
+)
+
+type chainPaginator struct {
+	paginators []scrape.Paginator
+}
+
+// Chain returns a Paginator that tries each of paginators in order and
+// returns the first non-empty URL, falling through to the next on "" or
+// error. This is useful for sites that sometimes expose a "Next »" anchor
+// and sometimes fall back to a numbered query parameter.
+func Chain(paginators ...scrape.Paginator) scrape.Paginator {
+	return &chainPaginator{paginators: paginators}
+}
+
+func (p *chainPaginator) NextPage(uri string, doc *goquery.Selection) (string, error) {
+	var lastErr error
+	for _, paginator := range p.paginators {
+		next, err := paginator.NextPage(uri, doc)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if next != "" {
+			return next, nil
+		}
+	}
+	return "", lastErr
+}
+
+// RawPaginator extends Paginator for sites that hide the next page URL
+// somewhere goquery can't reach, such as inside a <script> tag or an
+// onclick handler. A scraper that sees a Paginator implementing this
+// interface should call NextPageFromBody with the page's raw bytes.
+type RawPaginator interface {
+	scrape.Paginator
+	NextPageFromBody(uri string, body []byte) (string, error)
+}
+
+type byRegexPaginator struct {
+	re    *regexp.Regexp
+	group int
+}
+
+// ByRegex returns a RawPaginator that extracts the next page URL by running
+// re against the raw HTML of the page and resolving the given capture group
+// against the current URL. This is necessary for sites that bury pagination
+// data inside <script> tags, JSON blobs, or onclick handlers that goquery
+// cannot select. pattern is compiled with regexp.MustCompile, so a malformed
+// pattern panics at setup time rather than surfacing as a returned error,
+// matching the other constructors in this package.
+func ByRegex(pattern string, group int) scrape.Paginator {
+	return &byRegexPaginator{re: regexp.MustCompile(pattern), group: group}
+}
+
+// NextPage is a no-op stub so byRegexPaginator satisfies Paginator; the raw
+// body isn't available here, so real callers should be using
+// NextPageFromBody instead.
+func (p *byRegexPaginator) NextPage(_ string, _ *goquery.Selection) (string, error) {
+	return "", nil
+}
+
+func (p *byRegexPaginator) NextPageFromBody(uri string, body []byte) (string, error) {
+	match := p.re.FindSubmatch(body)
+	if match == nil || p.group >= len(match) {
+		return "", nil
+	}
+
+	val := string(match[p.group])
+	if val == "" {
+		return "", nil
+	}
+
+	base, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	target, err := url.Parse(val)
+	if err != nil {
+		return "", err
+	}
+
+	return base.ResolveReference(target).String(), nil
+}
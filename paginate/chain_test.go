@@ -0,0 +1,116 @@
+package paginate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+type stubPaginator struct {
+	next string
+	err  error
+}
+
+func (p *stubPaginator) NextPage(_ string, _ *goquery.Selection) (string, error) {
+	return p.next, p.err
+}
+
+func TestChainFallsThroughToFirstNonEmpty(t *testing.T) {
+	p := Chain(
+		&stubPaginator{next: ""},
+		&stubPaginator{next: "https://example.com/page-2.html"},
+		&stubPaginator{next: "https://example.com/other.html"},
+	)
+
+	next, err := p.NextPage("https://example.com/page-1.html", nil)
+	if err != nil {
+		t.Fatalf("NextPage returned error: %v", err)
+	}
+	if want := "https://example.com/page-2.html"; next != want {
+		t.Errorf("NextPage() = %q, want %q", next, want)
+	}
+}
+
+func TestChainSkipsErroringPaginators(t *testing.T) {
+	p := Chain(
+		&stubPaginator{err: errors.New("boom")},
+		&stubPaginator{next: "https://example.com/page-2.html"},
+	)
+
+	next, err := p.NextPage("https://example.com/page-1.html", nil)
+	if err != nil {
+		t.Fatalf("NextPage returned error: %v", err)
+	}
+	if want := "https://example.com/page-2.html"; next != want {
+		t.Errorf("NextPage() = %q, want %q", next, want)
+	}
+}
+
+func TestChainPropagatesErrorWhenAllFail(t *testing.T) {
+	errA := errors.New("first paginator failed")
+	errB := errors.New("second paginator failed")
+	p := Chain(
+		&stubPaginator{err: errA},
+		&stubPaginator{err: errB},
+	)
+
+	next, err := p.NextPage("https://example.com/page-1.html", nil)
+	if next != "" {
+		t.Errorf("NextPage() next = %q, want empty", next)
+	}
+	if !errors.Is(err, errB) {
+		t.Errorf("NextPage() err = %v, want %v", err, errB)
+	}
+}
+
+func TestChainReturnsNoErrorWhenAllExhausted(t *testing.T) {
+	p := Chain(
+		&stubPaginator{next: ""},
+		&stubPaginator{next: ""},
+	)
+
+	next, err := p.NextPage("https://example.com/page-1.html", nil)
+	if err != nil {
+		t.Fatalf("NextPage returned error: %v", err)
+	}
+	if next != "" {
+		t.Errorf("NextPage() = %q, want empty", next)
+	}
+}
+
+func TestByRegexNextPageFromBody(t *testing.T) {
+	p := ByRegex(`"nextPage":"([^"]+)"`, 1).(RawPaginator)
+
+	next, err := p.NextPageFromBody("https://example.com/list", []byte(`{"nextPage":"/list?page=2"}`))
+	if err != nil {
+		t.Fatalf("NextPageFromBody returned error: %v", err)
+	}
+	if want := "https://example.com/list?page=2"; next != want {
+		t.Errorf("NextPageFromBody() = %q, want %q", next, want)
+	}
+}
+
+func TestByRegexNextPageFromBodyNoMatch(t *testing.T) {
+	p := ByRegex(`"nextPage":"([^"]+)"`, 1).(RawPaginator)
+
+	next, err := p.NextPageFromBody("https://example.com/list", []byte(`{"other":"value"}`))
+	if err != nil {
+		t.Fatalf("NextPageFromBody returned error: %v", err)
+	}
+	if next != "" {
+		t.Errorf("NextPageFromBody() = %q, want empty", next)
+	}
+}
+
+func TestByRegexNextPageFromBodyGroupOutOfRange(t *testing.T) {
+	p := ByRegex(`"nextPage":"([^"]+)"`, 2).(RawPaginator)
+
+	next, err := p.NextPageFromBody("https://example.com/list", []byte(`{"nextPage":"/list?page=2"}`))
+	if err != nil {
+		t.Fatalf("NextPageFromBody returned error: %v", err)
+	}
+	if next != "" {
+		t.Errorf("NextPageFromBody() = %q, want empty", next)
+	}
+}
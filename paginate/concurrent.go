@@ -0,0 +1,353 @@
+package paginate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andrew-d/goscrape"
+)
+
+// PredictivePaginator is implemented by paginators whose next n page URLs
+// can be computed up-front, without inspecting the current page's DOM. The
+// scraper uses this to prefetch pages concurrently instead of walking them
+// one at a time; selector-based paginators such as BySelector can't
+// implement it, since the next URL depends on the rendered HTML, and fall
+// back to sequential fetching.
+type PredictivePaginator interface {
+	scrape.Paginator
+	PredictNextPages(currentURL string, n int) ([]string, error)
+}
+
+// CountObserver is implemented by predictive paginators that still need to
+// inspect a fetched page's content to update their own state - such as
+// WithTotalCount, which learns the site's total page count from the page
+// body rather than the URL. ConcurrentScrape never calls NextPage on a
+// PredictivePaginator, so it instead feeds each delivered document to
+// ObserveCount, in page order, for any such paginator that also implements
+// this interface.
+type CountObserver interface {
+	ObserveCount(doc *goquery.Selection) error
+}
+
+// RetryableStatusError lets a Fetcher report that a request failed with a
+// transient HTTP status - 429 or 503 - so fetchWithRetry knows to back off
+// and try again rather than giving up immediately.
+type RetryableStatusError struct {
+	StatusCode int
+}
+
+func (e *RetryableStatusError) Error() string {
+	return fmt.Sprintf("paginate: retryable status %d", e.StatusCode)
+}
+
+// Fetcher fetches a single URL and returns its parsed document. Callers
+// supply this so ConcurrentScrape stays decoupled from goscrape's own HTTP
+// client; a Fetcher should return a *RetryableStatusError for 429/503
+// responses so ConcurrentScrape retries them with backoff.
+type Fetcher func(ctx context.Context, url string) (*goquery.Selection, error)
+
+// ConcurrentConfig configures ConcurrentScrape.
+type ConcurrentConfig struct {
+	// Workers is the size of the worker pool used to fetch pages.
+	Workers int
+	// RequestsPerSecond caps the aggregate request rate per host.
+	RequestsPerSecond float64
+	// PerHostConcurrency caps how many in-flight requests a single host
+	// may have at once, independent of Workers.
+	PerHostConcurrency int
+}
+
+// ConcurrentScrape walks paginator starting at startURL, fetching pages with
+// fetch. When paginator implements PredictivePaginator, pages are predicted
+// and fetched cfg.Workers at a time, rate-limited per host and retried with
+// exponential backoff on 429/503, continuing batch after batch until
+// PredictNextPages runs dry; results are delivered on the returned channel
+// in page order even though fetches within a batch complete out of order.
+// Paginators that don't implement PredictivePaginator fall back to
+// sequential fetching, one page at a time.
+func ConcurrentScrape(ctx context.Context, startURL string, paginator scrape.Paginator, fetch Fetcher, cfg ConcurrentConfig) (<-chan *goquery.Selection, <-chan error) {
+	out := make(chan *goquery.Selection)
+	errc := make(chan error, 1)
+
+	predictive, ok := paginator.(PredictivePaginator)
+	if !ok {
+		go sequentialScrape(ctx, startURL, paginator, fetch, out, errc)
+		return out, errc
+	}
+
+	go concurrentScrape(ctx, startURL, predictive, fetch, cfg, out, errc)
+	return out, errc
+}
+
+func sequentialScrape(ctx context.Context, startURL string, paginator scrape.Paginator, fetch Fetcher, out chan<- *goquery.Selection, errc chan<- error) {
+	defer close(out)
+	defer close(errc)
+
+	u := startURL
+	for u != "" {
+		doc, err := fetch(ctx, u)
+		if err != nil {
+			errc <- err
+			return
+		}
+		out <- doc
+
+		next, err := paginator.NextPage(u, doc)
+		if err != nil {
+			errc <- err
+			return
+		}
+		u = next
+	}
+}
+
+type concurrentResult struct {
+	index int
+	doc   *goquery.Selection
+	err   error
+}
+
+func concurrentScrape(ctx context.Context, startURL string, paginator PredictivePaginator, fetch Fetcher, cfg ConcurrentConfig, out chan<- *goquery.Selection, errc chan<- error) {
+	defer close(out)
+	defer close(errc)
+
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+
+	limiter := newHostLimiter(cfg.RequestsPerSecond, cfg.PerHostConcurrency)
+	observer, _ := paginator.(CountObserver)
+
+	urls, err := paginator.PredictNextPages(startURL, cfg.Workers-1)
+	if err != nil {
+		errc <- err
+		return
+	}
+	urls = append([]string{startURL}, urls...)
+
+	// Fetch and drain one batch of up to cfg.Workers pages at a time, then
+	// predict the next batch from the last page delivered. This continues
+	// until PredictNextPages runs dry (e.g. WithTotalCount has seen the
+	// last page) or an error/cancellation stops the scrape.
+	for len(urls) > 0 {
+		lastURL, err := fetchBatch(ctx, urls, fetch, limiter, cfg.Workers, observer, out)
+		if err != nil {
+			errc <- err
+			return
+		}
+
+		urls, err = paginator.PredictNextPages(lastURL, cfg.Workers)
+		if err != nil {
+			errc <- err
+			return
+		}
+	}
+}
+
+// fetchBatch fetches urls concurrently, bounded by maxWorkers and limiter,
+// and delivers the results on out in their original order even though the
+// underlying fetches complete out of order. If observer is non-nil, each
+// document is passed to it, in that same order, before being forwarded to
+// out. It returns the last URL in the batch so the caller can predict the
+// next one.
+func fetchBatch(ctx context.Context, urls []string, fetch Fetcher, limiter *hostLimiter, maxWorkers int, observer CountObserver, out chan<- *goquery.Selection) (string, error) {
+	results := make(chan concurrentResult, len(urls))
+	sem := make(chan struct{}, maxWorkers)
+
+	var wg sync.WaitGroup
+	for i, u := range urls {
+		wg.Add(1)
+		go func(i int, u string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results <- concurrentResult{index: i, err: ctx.Err()}
+				return
+			}
+			defer func() { <-sem }()
+
+			release, err := limiter.acquire(ctx, u)
+			if err != nil {
+				results <- concurrentResult{index: i, err: err}
+				return
+			}
+
+			doc, err := fetchWithRetry(ctx, fetch, u)
+			release()
+			results <- concurrentResult{index: i, doc: doc, err: err}
+		}(i, u)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	// Reorder buffer: fetches complete out of order, so hold each result
+	// until it's its turn before handing it to the caller.
+	buffer := make(map[int]concurrentResult)
+	next := 0
+	for res := range results {
+		buffer[res.index] = res
+		for {
+			r, ok := buffer[next]
+			if !ok {
+				break
+			}
+			delete(buffer, next)
+			if r.err != nil {
+				return "", r.err
+			}
+			if observer != nil {
+				if err := observer.ObserveCount(r.doc); err != nil {
+					return "", err
+				}
+			}
+			out <- r.doc
+			next++
+		}
+	}
+
+	return urls[len(urls)-1], nil
+}
+
+// fetchWithRetry retries fetch with exponential backoff when it fails with
+// a *RetryableStatusError (429/503), and gives up immediately on any other
+// error.
+func fetchWithRetry(ctx context.Context, fetch Fetcher, u string) (*goquery.Selection, error) {
+	const maxAttempts = 5
+	backoff := 500 * time.Millisecond
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		doc, err := fetch(ctx, u)
+		if err == nil {
+			return doc, nil
+		}
+
+		lastErr = err
+		var retryable *RetryableStatusError
+		if !errors.As(err, &retryable) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return nil, fmt.Errorf("paginate: giving up on %s after %d attempts: %w", u, maxAttempts, lastErr)
+}
+
+// hostLimiter is a per-host token bucket that keeps ConcurrentScrape polite:
+// it caps both the aggregate request rate and the number of in-flight
+// requests for any one host.
+type hostLimiter struct {
+	rps     float64
+	perHost int
+
+	mu        sync.Mutex
+	tokens    map[string]float64
+	lastCheck map[string]time.Time
+	inFlight  map[string]chan struct{}
+}
+
+func newHostLimiter(rps float64, perHost int) *hostLimiter {
+	return &hostLimiter{
+		rps:       rps,
+		perHost:   perHost,
+		tokens:    make(map[string]float64),
+		lastCheck: make(map[string]time.Time),
+		inFlight:  make(map[string]chan struct{}),
+	}
+}
+
+// acquire blocks until rawURL's host has both an available in-flight slot
+// and a rate-limit token, then returns a release func that the caller must
+// invoke once its fetch of rawURL completes to free the in-flight slot.
+func (l *hostLimiter) acquire(ctx context.Context, rawURL string) (func(), error) {
+	host := hostOf(rawURL)
+	release := func() {}
+
+	if l.perHost > 0 {
+		l.mu.Lock()
+		slot, ok := l.inFlight[host]
+		if !ok {
+			slot = make(chan struct{}, l.perHost)
+			l.inFlight[host] = slot
+		}
+		l.mu.Unlock()
+
+		select {
+		case slot <- struct{}{}:
+			release = func() { <-slot }
+		case <-ctx.Done():
+			return func() {}, ctx.Err()
+		}
+	}
+
+	if l.rps <= 0 {
+		return release, nil
+	}
+
+	for {
+		wait, ok := l.takeToken(host)
+		if ok {
+			return release, nil
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			release()
+			return func() {}, ctx.Err()
+		}
+	}
+}
+
+func (l *hostLimiter) takeToken(host string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The bucket holds at least one token, so a rate below one request per
+	// second still lets a request through once a whole token has accrued.
+	capacity := math.Max(1, l.rps)
+
+	now := time.Now()
+	last, ok := l.lastCheck[host]
+	if !ok {
+		last = now
+		l.tokens[host] = capacity
+	}
+
+	elapsed := now.Sub(last).Seconds()
+	tokens := math.Min(capacity, l.tokens[host]+elapsed*l.rps)
+	l.lastCheck[host] = now
+
+	if tokens >= 1 {
+		l.tokens[host] = tokens - 1
+		return 0, true
+	}
+
+	l.tokens[host] = tokens
+	return time.Duration((1 - tokens) / l.rps * float64(time.Second)), false
+}
+
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	return u.Host
+}
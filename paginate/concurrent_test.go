@@ -0,0 +1,77 @@
+package paginate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// fakePaginator is a minimal PredictivePaginator that paginates a fixed
+// number of query-param pages, for exercising ConcurrentScrape without a
+// real HTTP server.
+type fakePaginator struct {
+	total int
+}
+
+func (p *fakePaginator) NextPage(uri string, _ *goquery.Selection) (string, error) {
+	return "", nil
+}
+
+func (p *fakePaginator) PredictNextPages(currentURL string, n int) ([]string, error) {
+	var current int
+	if _, err := fmt.Sscanf(currentURL, "https://example.com/items?page=%d", &current); err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, 0, n)
+	for i := 1; i <= n && current+i <= p.total; i++ {
+		urls = append(urls, fmt.Sprintf("https://example.com/items?page=%d", current+i))
+	}
+	return urls, nil
+}
+
+func fakeDoc(t *testing.T, body string) *goquery.Selection {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to build fake doc: %v", err)
+	}
+	return doc.Selection
+}
+
+func TestConcurrentScrapeWithTotalCount(t *testing.T) {
+	const totalPages = 5
+
+	fetch := func(_ context.Context, u string) (*goquery.Selection, error) {
+		return fakeDoc(t, fmt.Sprintf(`<span class="count">%d</span>`, totalPages*10)), nil
+	}
+
+	extractor := func(doc *goquery.Selection) (int, error) {
+		var n int
+		_, err := fmt.Sscanf(doc.Find(".count").Text(), "%d", &n)
+		return n, err
+	}
+
+	paginator := WithTotalCount(extractor, 10, &fakePaginator{total: totalPages})
+
+	out, errc := ConcurrentScrape(context.Background(), "https://example.com/items?page=1", paginator, fetch, ConcurrentConfig{Workers: 3})
+
+	var mu sync.Mutex
+	count := 0
+	for range out {
+		mu.Lock()
+		count++
+		mu.Unlock()
+	}
+	if err := <-errc; err != nil {
+		t.Fatalf("ConcurrentScrape returned error: %v", err)
+	}
+
+	if count != totalPages {
+		t.Fatalf("ConcurrentScrape delivered %d pages, want %d", count, totalPages)
+	}
+}
@@ -0,0 +1,106 @@
+package paginate
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andrew-d/goscrape"
+)
+
+// HeaderPaginator extends Paginator for sites that advertise the next page
+// in a response header instead of the document body. A scraper that sees a
+// Paginator implementing this interface should call NextPageFromHeader with
+// the header from the page it just fetched.
+type HeaderPaginator interface {
+	scrape.Paginator
+	NextPageFromHeader(uri string, header http.Header) (string, error)
+}
+
+type byLinkHeaderPaginator struct {
+	rel string
+}
+
+// ByLinkHeader returns a HeaderPaginator that derives the next page URL from
+// the RFC 5988 Link header of the previous response, e.g.
+//
+//	Link: <https://api.example.com/items?page=2>; rel="next"
+//
+// This is how GitHub-style REST APIs paginate, and lets goscrape follow JSON
+// and other non-HTML endpoints that don't embed a next-page anchor in the
+// body. rel selects which link relation to follow; pass "" to default to
+// "next".
+func ByLinkHeader(rel string) HeaderPaginator {
+	if rel == "" {
+		rel = "next"
+	}
+	return &byLinkHeaderPaginator{rel: rel}
+}
+
+// NextPage is a no-op stub so byLinkHeaderPaginator satisfies Paginator;
+// the Link header isn't available here, so real callers should be using
+// NextPageFromHeader instead.
+func (p *byLinkHeaderPaginator) NextPage(_ string, _ *goquery.Selection) (string, error) {
+	return "", nil
+}
+
+func (p *byLinkHeaderPaginator) NextPageFromHeader(uri string, header http.Header) (string, error) {
+	base, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+
+	for _, link := range parseLinkHeader(header.Get("Link")) {
+		if link.rel != p.rel {
+			continue
+		}
+
+		target, err := url.Parse(link.url)
+		if err != nil {
+			return "", err
+		}
+
+		return base.ResolveReference(target).String(), nil
+	}
+
+	return "", nil
+}
+
+type linkHeaderValue struct {
+	url string
+	rel string
+}
+
+// parseLinkHeader parses an RFC 5988 Link header value, e.g.
+//
+//	<https://api.example.com/items?page=2>; rel="next", <https://api.example.com/items?page=1>; rel="prev"
+func parseLinkHeader(header string) []linkHeaderValue {
+	var links []linkHeaderValue
+
+	for _, part := range strings.Split(header, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		segments := strings.Split(part, ";")
+		urlPart := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(urlPart, "<") || !strings.HasSuffix(urlPart, ">") {
+			continue
+		}
+		link := linkHeaderValue{url: urlPart[1 : len(urlPart)-1]}
+
+		for _, param := range segments[1:] {
+			kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+			if len(kv) != 2 || strings.TrimSpace(kv[0]) != "rel" {
+				continue
+			}
+			link.rel = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+		}
+
+		links = append(links, link)
+	}
+
+	return links
+}
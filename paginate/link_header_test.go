@@ -0,0 +1,115 @@
+package paginate
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []linkHeaderValue
+	}{
+		{
+			name:   "empty",
+			header: "",
+			want:   nil,
+		},
+		{
+			name:   "single link",
+			header: `<https://api.example.com/items?page=2>; rel="next"`,
+			want: []linkHeaderValue{
+				{url: "https://api.example.com/items?page=2", rel: "next"},
+			},
+		},
+		{
+			name:   "multiple links, quoted rels",
+			header: `<https://api.example.com/items?page=2>; rel="next", <https://api.example.com/items?page=1>; rel="prev"`,
+			want: []linkHeaderValue{
+				{url: "https://api.example.com/items?page=2", rel: "next"},
+				{url: "https://api.example.com/items?page=1", rel: "prev"},
+			},
+		},
+		{
+			name:   "unquoted rel",
+			header: `<https://api.example.com/items?page=2>; rel=next`,
+			want: []linkHeaderValue{
+				{url: "https://api.example.com/items?page=2", rel: "next"},
+			},
+		},
+		{
+			name:   "relative URL",
+			header: `</items?page=2>; rel="next"`,
+			want: []linkHeaderValue{
+				{url: "/items?page=2", rel: "next"},
+			},
+		},
+		{
+			name:   "extra params ignored",
+			header: `<https://api.example.com/items?page=2>; rel="next"; title="Next page"`,
+			want: []linkHeaderValue{
+				{url: "https://api.example.com/items?page=2", rel: "next"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseLinkHeader(tt.header)
+			if len(got) != len(tt.want) {
+				t.Fatalf("parseLinkHeader(%q) = %#v, want %#v", tt.header, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parseLinkHeader(%q)[%d] = %#v, want %#v", tt.header, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestByLinkHeaderNextPageFromHeader(t *testing.T) {
+	p := ByLinkHeader("")
+
+	header := http.Header{}
+	header.Set("Link", `<https://api.example.com/items?page=3>; rel="next", <https://api.example.com/items?page=1>; rel="prev"`)
+
+	next, err := p.NextPageFromHeader("https://api.example.com/items?page=2", header)
+	if err != nil {
+		t.Fatalf("NextPageFromHeader returned error: %v", err)
+	}
+	if want := "https://api.example.com/items?page=3"; next != want {
+		t.Errorf("NextPageFromHeader() = %q, want %q", next, want)
+	}
+}
+
+func TestByLinkHeaderNextPageFromHeaderRelativeURL(t *testing.T) {
+	p := ByLinkHeader("next")
+
+	header := http.Header{}
+	header.Set("Link", `</items?page=3>; rel="next"`)
+
+	next, err := p.NextPageFromHeader("https://api.example.com/items?page=2", header)
+	if err != nil {
+		t.Fatalf("NextPageFromHeader returned error: %v", err)
+	}
+	if want := "https://api.example.com/items?page=3"; next != want {
+		t.Errorf("NextPageFromHeader() = %q, want %q", next, want)
+	}
+}
+
+func TestByLinkHeaderNextPageFromHeaderNoMatch(t *testing.T) {
+	p := ByLinkHeader("next")
+
+	header := http.Header{}
+	header.Set("Link", `<https://api.example.com/items?page=1>; rel="prev"`)
+
+	next, err := p.NextPageFromHeader("https://api.example.com/items?page=2", header)
+	if err != nil {
+		t.Fatalf("NextPageFromHeader returned error: %v", err)
+	}
+	if next != "" {
+		t.Errorf("NextPageFromHeader() = %q, want empty", next)
+	}
+}
@@ -0,0 +1,89 @@
+package paginate
+
+import (
+	"fmt"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andrew-d/goscrape"
+)
+
+type pageTemplatePaginator struct {
+	tmpl  string
+	page  int
+	step  int
+	equal func(a, b *goquery.Selection) bool
+	prev  *goquery.Selection
+}
+
+// PageTemplateOption configures a Paginator returned by ByPageTemplate.
+type PageTemplateOption func(*pageTemplatePaginator)
+
+// WithPageEquality overrides how ByPageTemplate decides it has run off the
+// end of an archive: once NextPage sees a page whose content is "equal" to
+// the previous page's, per equal, pagination stops. The default compares
+// the full text of the two selections.
+func WithPageEquality(equal func(a, b *goquery.Selection) bool) PageTemplateOption {
+	return func(p *pageTemplatePaginator) {
+		p.equal = equal
+	}
+}
+
+// ByPageTemplate returns a Paginator that generates successive URLs by
+// substituting an incrementing page counter into tmpl (e.g.
+// "https://example.com/archive/page-%03d.html" or
+// "https://example.com/search?p=%d"), starting at start and advancing by
+// step each page. Unlike ByQueryParam, the starting URL doesn't need to
+// already contain the counter, and the counter may appear anywhere in tmpl -
+// path segment or query string.
+//
+// ByPageTemplate has no way of knowing on its own when an archive ends, so
+// pagination stops once the fetched page's content is the same as the
+// previous page's (see WithPageEquality) or a wrapping LimitPages is
+// exhausted.
+func ByPageTemplate(tmpl string, start, step int, opts ...PageTemplateOption) scrape.Paginator {
+	p := &pageTemplatePaginator{
+		tmpl:  tmpl,
+		page:  start,
+		step:  step,
+		equal: defaultPageEqual,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func defaultPageEqual(a, b *goquery.Selection) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	return a.Text() == b.Text()
+}
+
+func (p *pageTemplatePaginator) NextPage(_ string, doc *goquery.Selection) (string, error) {
+	if p.equal(p.prev, doc) {
+		return "", nil
+	}
+	p.prev = doc
+
+	p.page += p.step
+	return fmt.Sprintf(p.tmpl, p.page), nil
+}
+
+// PredictNextPages implements PredictivePaginator: the URL for a given page
+// number depends only on tmpl and the counter, so the following n URLs can
+// be computed up-front. Like NextPage, it advances the internal page
+// counter - callers are expected to call it instead of NextPage, not in
+// addition to it - so that successive calls keep generating fresh pages
+// rather than repeating the same batch. It does not apply the
+// WithPageEquality check: that check is only meaningful once a page has
+// actually been fetched, so ConcurrentScrape's caller is still responsible
+// for stopping the scrape once the site runs out of pages.
+func (p *pageTemplatePaginator) PredictNextPages(_ string, n int) ([]string, error) {
+	urls := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		p.page += p.step
+		urls = append(urls, fmt.Sprintf(p.tmpl, p.page))
+	}
+	return urls, nil
+}
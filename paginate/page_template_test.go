@@ -0,0 +1,48 @@
+package paginate
+
+import "testing"
+
+func TestPageTemplatePaginatorNextPageAdvances(t *testing.T) {
+	p := ByPageTemplate("https://example.com/page-%d.html", 1, 1)
+
+	next, err := p.NextPage("https://example.com/page-1.html", nil)
+	if err != nil {
+		t.Fatalf("NextPage returned error: %v", err)
+	}
+	if want := "https://example.com/page-2.html"; next != want {
+		t.Fatalf("NextPage() = %q, want %q", next, want)
+	}
+
+	next, err = p.NextPage("https://example.com/page-2.html", nil)
+	if err != nil {
+		t.Fatalf("NextPage returned error: %v", err)
+	}
+	if want := "https://example.com/page-3.html"; next != want {
+		t.Fatalf("NextPage() = %q, want %q", next, want)
+	}
+}
+
+func TestPageTemplatePaginatorPredictNextPagesAdvances(t *testing.T) {
+	p := ByPageTemplate("https://example.com/page-%d.html", 1, 1)
+
+	urls, err := p.(interface {
+		PredictNextPages(string, int) ([]string, error)
+	}).PredictNextPages("https://example.com/page-1.html", 3)
+	if err != nil {
+		t.Fatalf("PredictNextPages returned error: %v", err)
+	}
+
+	want := []string{
+		"https://example.com/page-2.html",
+		"https://example.com/page-3.html",
+		"https://example.com/page-4.html",
+	}
+	if len(urls) != len(want) {
+		t.Fatalf("PredictNextPages() = %v, want %v", urls, want)
+	}
+	for i := range urls {
+		if urls[i] != want[i] {
+			t.Errorf("PredictNextPages()[%d] = %q, want %q", i, urls[i], want[i])
+		}
+	}
+}
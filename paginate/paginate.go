@@ -87,6 +87,44 @@ func (p *byQueryParamPaginator) NextPage(u string, _ *goquery.Selection) (string
 	return uri.String(), nil
 }
 
+// PredictNextPages implements PredictivePaginator: since the next URL only
+// depends on incrementing p.param, the following n URLs can be computed
+// without fetching or inspecting any of the intervening pages.
+func (p *byQueryParamPaginator) PredictNextPages(u string, n int) ([]string, error) {
+	uri, err := url.Parse(u)
+	if err != nil {
+		return nil, err
+	}
+
+	vals, err := url.ParseQuery(uri.RawQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	params, ok := vals[p.param]
+	if !ok || len(params) < 1 {
+		return nil, nil
+	}
+
+	current, err := strconv.ParseUint(params[0], 10, 64)
+	if err != nil {
+		return nil, nil
+	}
+
+	urls := make([]string, 0, n)
+	for i := 1; i <= n; i++ {
+		next := *uri
+		v := make(url.Values, len(vals))
+		for k, vv := range vals {
+			v[k] = vv
+		}
+		v[p.param] = []string{strconv.FormatUint(current+uint64(i), 10)}
+		next.RawQuery = v.Encode()
+		urls = append(urls, next.String())
+	}
+	return urls, nil
+}
+
 type limitPagesPaginator struct {
 	current    int
 	limit      int
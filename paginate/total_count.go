@@ -0,0 +1,119 @@
+package paginate
+
+import (
+	"math"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andrew-d/goscrape"
+)
+
+// Pagination describes a site's own reporting of how many pages of results
+// it has, as scraped from something like a "Showing X of Y results" element
+// or an X-Total-Count-style meta tag.
+type Pagination struct {
+	Page       int
+	PerPage    int
+	TotalCount int
+	TotalPages int
+	Offset     int
+}
+
+type totalCountPaginator struct {
+	extractor  func(*goquery.Selection) (int, error)
+	perPage    int
+	underlying scrape.Paginator
+	onProgress func(Pagination)
+
+	page       int
+	totalPages int
+	known      bool
+}
+
+// TotalCountOption configures a Paginator returned by WithTotalCount.
+type TotalCountOption func(*totalCountPaginator)
+
+// WithProgress registers a callback invoked after each page with the
+// Pagination computed so far, so a caller can report "page N of M" or kick
+// off concurrent fetching of the remaining pages once the total is known.
+func WithProgress(onProgress func(Pagination)) TotalCountOption {
+	return func(p *totalCountPaginator) {
+		p.onProgress = onProgress
+	}
+}
+
+// WithTotalCount wraps underlying with a total-results count scraped from
+// each page via extractor, stopping pagination once ceil(total/perPage)
+// pages have been seen - superseding LimitPages when the site itself
+// advertises how many pages of results exist.
+func WithTotalCount(extractor func(*goquery.Selection) (int, error), perPage int, underlying scrape.Paginator, opts ...TotalCountOption) scrape.Paginator {
+	p := &totalCountPaginator{
+		extractor:  extractor,
+		perPage:    perPage,
+		underlying: underlying,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func (p *totalCountPaginator) NextPage(uri string, doc *goquery.Selection) (string, error) {
+	if err := p.ObserveCount(doc); err != nil {
+		return "", err
+	}
+
+	if p.page >= p.totalPages {
+		return "", nil
+	}
+
+	return p.underlying.NextPage(uri, doc)
+}
+
+// ObserveCount implements CountObserver: it extracts the total-results count
+// from doc and records progress, exactly as NextPage does, without asking
+// the underlying paginator for the next URL. ConcurrentScrape calls this for
+// every page it fetches instead of NextPage, since it drives pagination
+// through PredictNextPages and never fetches a page through NextPage itself.
+func (p *totalCountPaginator) ObserveCount(doc *goquery.Selection) error {
+	p.page++
+
+	total, err := p.extractor(doc)
+	if err != nil {
+		return err
+	}
+	if !p.known {
+		p.totalPages = int(math.Ceil(float64(total) / float64(p.perPage)))
+		p.known = true
+	}
+
+	if p.onProgress != nil {
+		p.onProgress(Pagination{
+			Page:       p.page,
+			PerPage:    p.perPage,
+			TotalCount: total,
+			TotalPages: p.totalPages,
+			Offset:     (p.page - 1) * p.perPage,
+		})
+	}
+
+	return nil
+}
+
+// PredictNextPages implements PredictivePaginator once the total page count
+// is known: it defers to the underlying paginator (if predictive itself),
+// capped at however many pages remain.
+func (p *totalCountPaginator) PredictNextPages(u string, n int) ([]string, error) {
+	predictive, ok := p.underlying.(PredictivePaginator)
+	if !ok || !p.known {
+		return nil, nil
+	}
+
+	if remaining := p.totalPages - p.page; remaining < n {
+		n = remaining
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+
+	return predictive.PredictNextPages(u, n)
+}
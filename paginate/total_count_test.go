@@ -0,0 +1,75 @@
+package paginate
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func countExtractor(total int) func(*goquery.Selection) (int, error) {
+	return func(_ *goquery.Selection) (int, error) {
+		return total, nil
+	}
+}
+
+func TestTotalCountPaginatorNextPage(t *testing.T) {
+	underlying := &stubPaginator{next: "https://example.com/items?page=2"}
+	p := WithTotalCount(countExtractor(25), 10, underlying)
+
+	next, err := p.NextPage("https://example.com/items?page=1", nil)
+	if err != nil {
+		t.Fatalf("NextPage returned error: %v", err)
+	}
+	if want := "https://example.com/items?page=2"; next != want {
+		t.Errorf("NextPage() = %q, want %q", next, want)
+	}
+}
+
+func TestTotalCountPaginatorStopsAtTotalPages(t *testing.T) {
+	underlying := &stubPaginator{next: "https://example.com/items?page=ignored"}
+	p := WithTotalCount(countExtractor(25), 10, underlying)
+
+	// perPage=10, total=25 -> ceil(25/10) = 3 pages.
+	for i := 1; i <= 2; i++ {
+		next, err := p.NextPage(fmt.Sprintf("https://example.com/items?page=%d", i), nil)
+		if err != nil {
+			t.Fatalf("NextPage(%d) returned error: %v", i, err)
+		}
+		if next == "" {
+			t.Fatalf("NextPage(%d) stopped early", i)
+		}
+	}
+
+	next, err := p.NextPage("https://example.com/items?page=3", nil)
+	if err != nil {
+		t.Fatalf("NextPage(3) returned error: %v", err)
+	}
+	if next != "" {
+		t.Errorf("NextPage(3) = %q, want empty once total pages are exhausted", next)
+	}
+}
+
+func TestTotalCountPaginatorWithProgress(t *testing.T) {
+	var seen []Pagination
+	underlying := &stubPaginator{next: "https://example.com/items?page=next"}
+	p := WithTotalCount(countExtractor(25), 10, underlying, WithProgress(func(pg Pagination) {
+		seen = append(seen, pg)
+	}))
+
+	if _, err := p.NextPage("https://example.com/items?page=1", nil); err != nil {
+		t.Fatalf("NextPage returned error: %v", err)
+	}
+	if _, err := p.NextPage("https://example.com/items?page=2", nil); err != nil {
+		t.Fatalf("NextPage returned error: %v", err)
+	}
+
+	if len(seen) != 2 {
+		t.Fatalf("onProgress called %d times, want 2", len(seen))
+	}
+
+	want := Pagination{Page: 2, PerPage: 10, TotalCount: 25, TotalPages: 3, Offset: 10}
+	if seen[1] != want {
+		t.Errorf("second Pagination = %+v, want %+v", seen[1], want)
+	}
+}